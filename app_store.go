@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	APP_STORE_RSS_URI = "https://itunes.apple.com/%s/rss/customerreviews/id=%s/sortBy=mostRecent/page=%d/xml"
+
+	// APP_STORE_MAX_PAGE mirrors Apple's own cap on the customer-reviews
+	// feed: pages past ~10 (roughly 500 reviews) stop being served as Atom
+	// and return an error body instead.
+	APP_STORE_MAX_PAGE = 10
+)
+
+func init() {
+	RegisterScraper(SOURCE_APP_STORE, AppStoreScraper{})
+}
+
+type AppStoreScraper struct{}
+
+type appStoreFeed struct {
+	XMLName xml.Name        `xml:"feed"`
+	Entries []appStoreEntry `xml:"entry"`
+}
+
+type appStoreEntry struct {
+	Author struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Title   string `xml:"title"`
+	Content string `xml:"content"`
+	Rating  string `xml:"rating"`
+	Updated string `xml:"updated"`
+	Id      string `xml:"id"`
+}
+
+// Fetch polls Apple's public customer-reviews RSS feed and maps each entry
+// onto the same Review struct the Google Play scraper produces.
+func (AppStoreScraper) Fetch(config Config) (Reviews, error) {
+	reviews := Reviews{}
+
+	for page := 1; len(reviews) < config.ReviewCount && page <= APP_STORE_MAX_PAGE; page++ {
+		uri := fmt.Sprintf(APP_STORE_RSS_URI, config.Country, config.AppStoreAppId, page)
+		log.Println(uri)
+
+		res, err := http.Get(uri)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		pageReviews, done := parseAppStoreFeedPage(body)
+		reviews = append(reviews, pageReviews...)
+
+		if done {
+			break
+		}
+	}
+
+	sort.Sort(reviews)
+
+	return reviews, nil
+}
+
+// parseAppStoreFeedPage maps one page of Apple's customer-reviews Atom feed
+// onto Review, skipping the first entry (the app/feed summary, not a
+// review) and any entry whose rating or timestamp fails to parse. done is
+// true once pagination should stop: either the feed ran out of review
+// entries, or the page isn't well-formed Atom at all, which is how Apple
+// responds past the feed's real page ceiling.
+func parseAppStoreFeedPage(body []byte) (reviews Reviews, done bool) {
+	feed := appStoreFeed{}
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		log.Println(err)
+		return nil, true
+	}
+
+	if len(feed.Entries) <= 1 {
+		return nil, true
+	}
+
+	for _, entry := range feed.Entries[1:] {
+		review, err := mapAppStoreEntry(entry)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, false
+}
+
+// mapAppStoreEntry maps one <entry> onto Review, returning an error when
+// its rating or updated timestamp doesn't parse.
+func mapAppStoreEntry(entry appStoreEntry) (Review, error) {
+	rating, err := strconv.Atoi(entry.Rating)
+	if err != nil {
+		return Review{}, err
+	}
+
+	updated, err := time.Parse(time.RFC3339, entry.Updated)
+	if err != nil {
+		return Review{}, err
+	}
+
+	return Review{
+		Author:    entry.Author.Name,
+		Title:     entry.Title,
+		Message:   entry.Content,
+		Rate:      strings.Repeat(RATING_EMOJI, rating),
+		Stars:     rating,
+		UpdatedAt: updated,
+		Permalink: entry.Id,
+		Platform:  SOURCE_APP_STORE,
+	}, nil
+}
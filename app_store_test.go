@@ -0,0 +1,106 @@
+package main
+
+import "testing"
+
+func TestMapAppStoreEntry(t *testing.T) {
+	entry := appStoreEntry{
+		Title:   "Solid update",
+		Content: "The new version fixed my sync issues.",
+		Rating:  "4",
+		Updated: "2020-03-03T00:00:00Z",
+		Id:      "https://itunes.apple.com/us/review?id=12345&type=Purple+Software",
+	}
+	entry.Author.Name = "Jon"
+
+	review, err := mapAppStoreEntry(entry)
+	if err != nil {
+		t.Fatalf("mapAppStoreEntry returned error: %v", err)
+	}
+
+	if review.Author != "Jon" {
+		t.Errorf("expected author Jon, got %s", review.Author)
+	}
+
+	if review.Message != "The new version fixed my sync issues." {
+		t.Errorf("expected message to be extracted, got %s", review.Message)
+	}
+
+	if review.Rate != ":star::star::star::star:" {
+		t.Errorf("expected 4 star emoji, got %s", review.Rate)
+	}
+
+	if review.Permalink != "https://itunes.apple.com/us/review?id=12345&type=Purple+Software" {
+		t.Errorf("unexpected permalink: %s", review.Permalink)
+	}
+
+	if review.Platform != SOURCE_APP_STORE {
+		t.Errorf("expected platform %s, got %s", SOURCE_APP_STORE, review.Platform)
+	}
+}
+
+func TestMapAppStoreEntryBadRating(t *testing.T) {
+	entry := appStoreEntry{Rating: "not-a-number", Updated: "2020-03-03T00:00:00Z"}
+
+	if _, err := mapAppStoreEntry(entry); err == nil {
+		t.Fatal("expected an error for an unparsable rating")
+	}
+}
+
+func TestParseAppStoreFeedPageSkipsFirstEntry(t *testing.T) {
+	body := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<feed>
+  <entry>
+    <title>App Name</title>
+  </entry>
+  <entry>
+    <author><name>Jon</name></author>
+    <title>Solid update</title>
+    <content>The new version fixed my sync issues.</content>
+    <rating>4</rating>
+    <updated>2020-03-03T00:00:00Z</updated>
+    <id>https://itunes.apple.com/us/review?id=12345&amp;type=Purple+Software</id>
+  </entry>
+</feed>`)
+
+	reviews, done := parseAppStoreFeedPage(body)
+	if done {
+		t.Fatal("expected pagination to continue when a page has review entries")
+	}
+
+	if len(reviews) != 1 {
+		t.Fatalf("expected the feed summary entry to be skipped, got %d reviews", len(reviews))
+	}
+
+	if reviews[0].Author != "Jon" {
+		t.Errorf("expected the remaining review's author to be Jon, got %s", reviews[0].Author)
+	}
+}
+
+func TestParseAppStoreFeedPageStopsOnEmptyFeed(t *testing.T) {
+	body := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<feed>
+  <entry>
+    <title>App Name</title>
+  </entry>
+</feed>`)
+
+	reviews, done := parseAppStoreFeedPage(body)
+	if !done {
+		t.Fatal("expected pagination to stop when only the feed summary entry is present")
+	}
+
+	if reviews != nil {
+		t.Errorf("expected no reviews, got %v", reviews)
+	}
+}
+
+func TestParseAppStoreFeedPageStopsOnUnparsableBody(t *testing.T) {
+	reviews, done := parseAppStoreFeedPage([]byte("Forbidden"))
+	if !done {
+		t.Fatal("expected pagination to stop when the page isn't well-formed Atom")
+	}
+
+	if reviews != nil {
+		t.Errorf("expected no reviews, got %v", reviews)
+	}
+}
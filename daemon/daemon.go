@@ -0,0 +1,127 @@
+// Package daemon turns JonSnow's one-shot fetch-and-post cycle into a
+// long-running poller: a cancellable tick loop, a token-bucket limiter so
+// overlapping fetches don't hammer a store, and a small HTTP server for
+// /healthz and Prometheus /metrics.
+package daemon
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type Config struct {
+	PollInterval time.Duration
+	Jitter       time.Duration
+	HealthAddr   string
+}
+
+type Metrics struct {
+	ReviewsFetchedTotal  prometheus.Counter
+	SlackPostErrorsTotal prometheus.Counter
+	DbInsertDuration     prometheus.Histogram
+}
+
+func NewMetrics() *Metrics {
+	metrics := &Metrics{
+		ReviewsFetchedTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "reviews_fetched_total",
+			Help: "Total number of reviews fetched across all sources.",
+		}),
+		SlackPostErrorsTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "slack_post_errors_total",
+			Help: "Total number of fetch cycles whose review post to a notification sink failed.",
+		}),
+		DbInsertDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name: "db_insert_duration_seconds",
+			Help: "Duration of SaveReviews' database inserts, in seconds.",
+		}),
+	}
+
+	prometheus.MustRegister(metrics.ReviewsFetchedTotal, metrics.SlackPostErrorsTotal, metrics.DbInsertDuration)
+
+	return metrics
+}
+
+// Limiter is a simple token bucket that bounds how many fetch cycles may
+// run at once, so a future multi-app daemon doesn't fan out unbounded
+// concurrent requests against a single store.
+type Limiter struct {
+	tokens chan struct{}
+}
+
+func NewLimiter(max int) *Limiter {
+	return &Limiter{tokens: make(chan struct{}, max)}
+}
+
+func (l *Limiter) Acquire() {
+	l.tokens <- struct{}{}
+}
+
+func (l *Limiter) Release() {
+	<-l.tokens
+}
+
+// Run calls tick on every poll interval (plus a random jitter up to
+// cfg.Jitter) until ctx is canceled or a SIGINT/SIGTERM is received, at
+// which point it shuts down the health/metrics server and returns.
+func Run(ctx context.Context, cfg Config, tick func(context.Context) error) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if cfg.HealthAddr != "" {
+		server := newHealthServer(cfg.HealthAddr)
+
+		go func() {
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Println(err)
+			}
+		}()
+
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			server.Shutdown(shutdownCtx)
+		}()
+	}
+
+	for {
+		if err := tick(ctx); err != nil {
+			log.Println(err)
+		}
+
+		wait := cfg.PollInterval
+		if cfg.Jitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(cfg.Jitter)))
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("daemon: shutting down")
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+func newHealthServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return &http.Server{Addr: addr, Handler: mux}
+}
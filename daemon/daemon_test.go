@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRunStopsWhenContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var ticks int
+	done := make(chan error, 1)
+
+	go func() {
+		done <- Run(ctx, Config{PollInterval: time.Millisecond}, func(context.Context) error {
+			ticks++
+			if ticks == 2 {
+				cancel()
+			}
+			return nil
+		})
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run returned error: %v", err)
+		}
+		if ticks < 2 {
+			t.Errorf("expected at least 2 ticks before shutdown, got %d", ticks)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run did not shut down after context cancellation")
+	}
+}
+
+func TestLimiterBoundsConcurrency(t *testing.T) {
+	limiter := NewLimiter(1)
+
+	limiter.Acquire()
+	defer limiter.Release()
+
+	acquired := make(chan struct{})
+	go func() {
+		limiter.Acquire()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("expected second Acquire to block while the first token is held")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// FileScraper reads reviews from a JSON fixture instead of hitting a live
+// store, so tests can exercise FetchAll and the merge/sort logic
+// deterministically. It is not registered in the default scraper registry.
+type FileScraper struct {
+	Path     string
+	Platform string
+}
+
+func (s FileScraper) Fetch(config Config) (Reviews, error) {
+	data, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := Reviews{}
+	if err := json.Unmarshal(data, &reviews); err != nil {
+		return nil, err
+	}
+
+	for i := range reviews {
+		reviews[i].Platform = s.Platform
+	}
+
+	return reviews, nil
+}
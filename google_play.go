@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	GOOGLE_PLAY_BASE_URI         = "https://play.google.com"
+	GOOGLE_PLAY_BATCHEXECUTE_URI = GOOGLE_PLAY_BASE_URI + "/_/PlayStoreUi/data/batchexecute?rpcids=UsvDTd"
+	GOOGLE_PLAY_PAGE_SIZE        = 40
+)
+
+func init() {
+	RegisterScraper(SOURCE_GOOGLE_PLAY, GooglePlayScraper{})
+}
+
+type GooglePlayScraper struct{}
+
+// Fetch pages through the same batchexecute RPC (`UsvDTd`) the Play Store
+// web client uses to render its review list, since the old server-rendered
+// `.single-review` markup no longer exists.
+func (GooglePlayScraper) Fetch(config Config) (Reviews, error) {
+	reviews := Reviews{}
+	token := ""
+
+	for len(reviews) < config.ReviewCount {
+		page, nextToken, err := fetchGooglePlayReviewPage(config.AppId, config.Location, token)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(page) == 0 {
+			break
+		}
+
+		reviews = append(reviews, page...)
+
+		if nextToken == "" {
+			break
+		}
+		token = nextToken
+	}
+
+	if len(reviews) > config.ReviewCount {
+		reviews = reviews[:config.ReviewCount]
+	}
+
+	sort.Sort(reviews)
+
+	return reviews, nil
+}
+
+func fetchGooglePlayReviewPage(appId, lang, token string) (Reviews, string, error) {
+	form := url.Values{}
+	form.Set("f.req", buildGooglePlayReviewRequest(appId, token))
+
+	req, err := http.NewRequest("POST", GOOGLE_PLAY_BATCHEXECUTE_URI+"&hl="+url.QueryEscape(lang), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=UTF-8")
+
+	log.Println(req.URL.String())
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rawReviews, nextToken, err := parseGooglePlayBatchexecuteResponse(body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	reviews := Reviews{}
+	for _, raw := range rawReviews {
+		review, err := mapGooglePlayReview(appId, raw)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, nextToken, nil
+}
+
+// buildGooglePlayReviewRequest mirrors the f.req payload google-play-scraper
+// reverse-engineered for the UsvDTd RPC: an app id, a page size, and an
+// opaque pagination token carried over from the previous page's response.
+func buildGooglePlayReviewRequest(appId, token string) string {
+	var pageToken interface{}
+	if token != "" {
+		pageToken = token
+	}
+
+	inner := []interface{}{
+		nil, nil,
+		[]interface{}{2, 2, []interface{}{GOOGLE_PLAY_PAGE_SIZE, nil, pageToken}, nil, []interface{}{}},
+		[]interface{}{appId, 7},
+	}
+	innerJson, _ := json.Marshal(inner)
+
+	outer := []interface{}{
+		[]interface{}{
+			[]interface{}{"UsvDTd", string(innerJson), nil, "generic"},
+		},
+	}
+	outerJson, _ := json.Marshal(outer)
+
+	return string(outerJson)
+}
+
+// parseGooglePlayBatchexecuteResponse strips the ")]}'" XSSI prefix, finds
+// the "wrb.fr" chunk for the UsvDTd RPC, and unmarshals its JSON-in-string
+// payload into the raw per-review arrays plus the next page token.
+func parseGooglePlayBatchexecuteResponse(body []byte) ([]interface{}, string, error) {
+	text := strings.TrimPrefix(string(body), ")]}'")
+
+	var dataJson string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "[[") {
+			continue
+		}
+
+		var envelope []interface{}
+		if err := json.Unmarshal([]byte(line), &envelope); err != nil {
+			continue
+		}
+
+		for _, chunk := range envelope {
+			row, ok := chunk.([]interface{})
+			if !ok || len(row) < 3 {
+				continue
+			}
+			if name, ok := row[0].(string); !ok || name != "wrb.fr" {
+				continue
+			}
+			if s, ok := row[2].(string); ok {
+				dataJson = s
+			}
+		}
+	}
+
+	if dataJson == "" {
+		return nil, "", fmt.Errorf("google play: no review data in batchexecute response")
+	}
+
+	var data []interface{}
+	if err := json.Unmarshal([]byte(dataJson), &data); err != nil {
+		return nil, "", err
+	}
+
+	if len(data) == 0 || data[0] == nil {
+		return nil, "", nil
+	}
+
+	rawReviews, _ := data[0].([]interface{})
+
+	var nextToken string
+	if len(data) > 1 {
+		if tokenContainer, ok := data[1].([]interface{}); ok && len(tokenContainer) > 0 {
+			nextToken, _ = tokenContainer[0].(string)
+		}
+	}
+
+	return rawReviews, nextToken, nil
+}
+
+// mapGooglePlayReview maps a single raw review row onto Review. Field
+// offsets follow the batchexecute response shape: author name at [0][0],
+// rating at [0][2], comment at [0][4], timestamp at [0][5][0], and
+// permalink id at [0][10][0].
+func mapGooglePlayReview(appId string, raw interface{}) (Review, error) {
+	entry, ok := raw.([]interface{})
+	if !ok || len(entry) == 0 {
+		return Review{}, fmt.Errorf("google play: unexpected review shape")
+	}
+
+	fields, ok := entry[0].([]interface{})
+	if !ok || len(fields) < 11 {
+		return Review{}, fmt.Errorf("google play: unexpected review field count")
+	}
+
+	author, _ := fields[0].(string)
+	message, _ := fields[4].(string)
+
+	rating, _ := fields[2].(float64)
+
+	var updatedAt time.Time
+	if ts, ok := fields[5].([]interface{}); ok && len(ts) > 0 {
+		if seconds, ok := ts[0].(float64); ok {
+			updatedAt = time.Unix(int64(seconds), 0)
+		}
+	}
+
+	var permalinkId string
+	if id, ok := fields[10].([]interface{}); ok && len(id) > 0 {
+		permalinkId, _ = id[0].(string)
+	}
+
+	return Review{
+		Author:    author,
+		Message:   message,
+		Rate:      strings.Repeat(RATING_EMOJI, int(rating)),
+		Stars:     int(rating),
+		UpdatedAt: updatedAt,
+		Permalink: fmt.Sprintf("%s/store/apps/details?id=%s&reviewId=%s", GOOGLE_PLAY_BASE_URI, appId, permalinkId),
+		Platform:  SOURCE_GOOGLE_PLAY,
+	}, nil
+}
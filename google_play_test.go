@@ -0,0 +1,68 @@
+package main
+
+import "testing"
+
+func TestMapGooglePlayReview(t *testing.T) {
+	raw := []interface{}{
+		[]interface{}{
+			"Daenerys", nil, float64(5), nil, "Loved the new update.",
+			[]interface{}{float64(1583020800)}, nil, nil, nil, nil,
+			[]interface{}{"gp:AOqpTOE"},
+		},
+	}
+
+	review, err := mapGooglePlayReview("com.example.app", raw)
+	if err != nil {
+		t.Fatalf("mapGooglePlayReview returned error: %v", err)
+	}
+
+	if review.Author != "Daenerys" {
+		t.Errorf("expected author Daenerys, got %s", review.Author)
+	}
+
+	if review.Message != "Loved the new update." {
+		t.Errorf("expected message to be extracted, got %s", review.Message)
+	}
+
+	if review.Rate != ":star::star::star::star::star:" {
+		t.Errorf("expected 5 star emoji, got %s", review.Rate)
+	}
+
+	if review.Permalink != "https://play.google.com/store/apps/details?id=com.example.app&reviewId=gp:AOqpTOE" {
+		t.Errorf("unexpected permalink: %s", review.Permalink)
+	}
+
+	if review.Platform != SOURCE_GOOGLE_PLAY {
+		t.Errorf("expected platform %s, got %s", SOURCE_GOOGLE_PLAY, review.Platform)
+	}
+}
+
+func TestParseGooglePlayBatchexecuteResponse(t *testing.T) {
+	data := `[null]`
+	envelope := `)]}'` + "\n" + `[["wrb.fr","UsvDTd","` + escapeForJson(data) + `",null]]`
+
+	reviews, nextToken, err := parseGooglePlayBatchexecuteResponse([]byte(envelope))
+	if err != nil {
+		t.Fatalf("parseGooglePlayBatchexecuteResponse returned error: %v", err)
+	}
+
+	if reviews != nil {
+		t.Errorf("expected no reviews for a nil data array, got %v", reviews)
+	}
+
+	if nextToken != "" {
+		t.Errorf("expected no next token, got %s", nextToken)
+	}
+}
+
+func escapeForJson(s string) string {
+	escaped := ""
+	for _, r := range s {
+		if r == '"' {
+			escaped += `\"`
+		} else {
+			escaped += string(r)
+		}
+	}
+	return escaped
+}
@@ -1,32 +1,50 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"database/sql"
-	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
-	"sort"
-	"strings"
+	"sync"
 	"time"
 
-	"github.com/PuerkitoBio/goquery"
 	"github.com/lib/pq"
+	"github.com/naineshzaveri/JonSnow/daemon"
+	"github.com/naineshzaveri/JonSnow/notify"
+	"github.com/naineshzaveri/JonSnow/sentiment"
 	"gopkg.in/yaml.v2"
 )
 
+const (
+	COLOR_GOOD    = "good"
+	COLOR_WARNING = "warning"
+	COLOR_DANGER  = "danger"
+
+	NEGATIVE_SENTIMENT_THRESHOLD = -0.5
+)
+
 type Config struct {
-	AppId       string `yaml:"app_id"`
-	ReviewCount int    `yaml:"review_count"`
-	BotName     string `yaml:"bot_name"`
-	IconEmoji   string `yaml:"icon_emoji"`
-	MessageText string `yaml:"message_text"`
-	WebHookUri  string `yaml:"web_hook_uri"`
-	Location    string `yaml:location`
+	AppId                string          `yaml:"app_id"`
+	AppStoreAppId        string          `yaml:"app_store_app_id"`
+	Country              string          `yaml:"country"`
+	Sources              []string        `yaml:"sources"`
+	ReviewCount          int             `yaml:"review_count"`
+	BotName              string          `yaml:"bot_name"`
+	IconEmoji            string          `yaml:"icon_emoji"`
+	MessageText          string          `yaml:"message_text"`
+	WebHookUri           string          `yaml:"web_hook_uri"`
+	Location             string          `yaml:location`
+	AlertThreshold       float64         `yaml:"alert_threshold"`
+	AlertWebHookUri      string          `yaml:"alert_web_hook_uri"`
+	PollInterval         string          `yaml:"poll_interval"`
+	Jitter               string          `yaml:"jitter"`
+	MaxConcurrentFetches int             `yaml:"max_concurrent_fetches"`
+	HealthAddr           string          `yaml:"health_addr"`
+	Sinks                []notify.Config `yaml:"sinks"`
 }
 
 type Review struct {
@@ -35,9 +53,11 @@ type Review struct {
 	Title     string
 	Message   string
 	Rate      string
+	Stars     int
 	UpdatedAt time.Time `meddler:"updated_at,localtime"`
 	Permalink string
 	Color     string
+	Platform  string
 }
 
 type Reviews []Review
@@ -46,49 +66,17 @@ type DBH struct {
 	*sql.DB
 }
 
-type SlackPayload struct {
-	Text        string            `json:"text"`
-	UserName    string            `json:"username"`
-	IconEmoji   string            `json:"icon_emoji"`
-	Attachments []SlackAttachment `json:"attachments"`
-}
-
-type SlackAttachment struct {
-	Author     string                 `json: author_name`
-	AuthorLink string                 `json: author_link`
-	Title      string                 `json:"title"`
-	TitleLink  string                 `json:"title_link"`
-	Text       string                 `json:"text"`
-	Fallback   string                 `json:"fallback"`
-	Color      string                 `json: color`
-	Fields     []SlackAttachmentField `json:"fields"`
-}
-
-type SlackAttachmentField struct {
-	Title string `json:"title"`
-	Value string `json:"value"`
-	Short bool   `json:"short"`
-}
-
 const (
-	TABLE_NAME                  = "review"
-	BASE_URI                    = "https://play.google.com"
-	REVIEW_CLASS_NAME           = ".single-review"
-	AUTHOR_NAME_CLASS_NAME      = ".review-info span.author-name"
-	REVIEW_DATE_CLASS_NAME      = ".review-info .review-date"
-	REVIEW_TITLE_CLASS_NAME     = ".review-body .review-title"
-	REVIEW_MESSAGE_CLASS_NAME   = ".review-body"
-	REVIEW_LINK_CLASS_NAME      = ".review-link"
-	REVIEW_RATE_CLASS_NAME      = ".review-info-star-rating .current-rating"
-	RATING_EMOJI                = ":star:"
-	RATING_EMOJI_2              = ":star2:"
-	MAX_REVIEW_NUM              = 40
-	REVIEW_PERMALINK_CLASS_NAME = ".review-info .reviews-permalink"
+	TABLE_NAME     = "review"
+	RATING_EMOJI   = ":star:"
+	RATING_EMOJI_2 = ":star2:"
+	MAX_REVIEW_NUM = 1000
 )
 
 var (
 	dbh        *DBH
 	configFile = flag.String("c", "./config.yml", "config file")
+	daemonMode = flag.Bool("daemon", false, "run as a long-running daemon instead of a single one-shot fetch")
 )
 
 func GetDBH() *DBH {
@@ -123,7 +111,7 @@ func NewConfig(path string) (config Config, err error) {
 	}
 
 	if config.ReviewCount > MAX_REVIEW_NUM || config.ReviewCount < 1 {
-		return config, fmt.Errorf("Please Set Num Between 1 and 40.")
+		return config, fmt.Errorf("Please Set Num Between 1 and %d.", MAX_REVIEW_NUM)
 	}
 
 	url := os.Getenv("DATABASE_URL")
@@ -166,22 +154,62 @@ func NewConfig(path string) (config Config, err error) {
 		config.Location = location
 	}
 
-	if config.AppId == "" {
-		return config, fmt.Errorf("Please Set Your Google Play App Id.")
+	if len(config.Sources) == 0 {
+		config.Sources = []string{SOURCE_GOOGLE_PLAY}
 	}
 
-	uri := fmt.Sprintf("%s/store/apps/details?id=%s", BASE_URI, config.AppId)
+	if config.PollInterval == "" {
+		config.PollInterval = "15m"
+	}
 
-	res, err := http.Get(uri)
-	if err != nil {
-		return config, err
+	if config.HealthAddr == "" {
+		config.HealthAddr = ":8080"
+	}
+
+	for _, source := range config.Sources {
+		if _, ok := GetScraper(source); !ok {
+			return config, fmt.Errorf("Unknown Source: %s", source)
+		}
+	}
+
+	for _, sink := range config.Sinks {
+		if _, err := notify.New(sink); err != nil {
+			return config, err
+		}
+	}
+
+	hasGooglePlay := false
+	for _, source := range config.Sources {
+		if source == SOURCE_GOOGLE_PLAY {
+			hasGooglePlay = true
+			break
+		}
+	}
+
+	if hasGooglePlay {
+		if config.AppId == "" {
+			return config, fmt.Errorf("Please Set Your Google Play App Id.")
+		}
+
+		uri := fmt.Sprintf("%s/store/apps/details?id=%s", GOOGLE_PLAY_BASE_URI, config.AppId)
+
+		res, err := http.Get(uri)
+		if err != nil {
+			return config, err
+		}
+
+		if res.StatusCode == http.StatusNotFound {
+			return config, fmt.Errorf("AppID: %s is not exists", config.AppId)
+		}
 	}
 
-	if res.StatusCode == http.StatusNotFound {
-		return config, fmt.Errorf("AppID: %s is not exists", config.AppId)
+	for _, source := range config.Sources {
+		if source == SOURCE_APP_STORE && (config.AppStoreAppId == "" || config.Country == "") {
+			return config, fmt.Errorf("Please Set app_store_app_id And Country For App Store Reviews.")
+		}
 	}
 
-	return config, err
+	return config, nil
 }
 
 func main() {
@@ -193,108 +221,85 @@ func main() {
 		return
 	}
 
-	reviews, err := GetReview(config)
-	if err != nil {
-		log.Println(err)
-		return
-	}
-
-	reviews, err = SaveReviews(reviews)
-	if err != nil {
-		log.Println(err)
-		return
-	}
+	if !*daemonMode {
+		if err := runOnce(config, nil); err != nil {
+			log.Println(err)
+			return
+		}
 
-	err = PostReview(config, reviews)
-	if err != nil {
-		log.Println(err)
+		log.Println("done")
 		return
 	}
 
-	log.Println("done")
+	runDaemon(config)
 }
 
-func GetReview(config Config) (Reviews, error) {
-	uri := fmt.Sprintf("%s/store/apps/details?id=%s&hl=%s", BASE_URI, config.AppId, config.Location)
-	log.Println(uri)
-	doc, err := goquery.NewDocument(uri)
-
+// runOnce performs a single fetch -> save -> post cycle. metrics may be nil,
+// in which case nothing is recorded (the original one-shot behavior).
+func runOnce(config Config, metrics *daemon.Metrics) error {
+	reviews, err := FetchAll(config)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	reviews := Reviews{}
-
-	doc.Find(REVIEW_CLASS_NAME).Each(func(i int, s *goquery.Selection) {
-		authorNode := s.Find(AUTHOR_NAME_CLASS_NAME)
-		authorName := authorNode.Text()
-		dateNode := s.Find(REVIEW_DATE_CLASS_NAME)
+	if metrics != nil {
+		metrics.ReviewsFetchedTotal.Add(float64(len(reviews)))
+	}
 
-		var timeForm string
-		if config.Location == "zh-tw" {
-			timeForm = "2006年1月2日"
-		} else if config.Location == "en" {
-			timeForm = "January 2, 2006"
-		}
+	start := time.Now()
+	reviews, err = SaveReviews(reviews)
+	if metrics != nil {
+		metrics.DbInsertDuration.Observe(time.Since(start).Seconds())
+	}
+	if err != nil {
+		return err
+	}
 
-		date, err := time.Parse(timeForm, dateNode.Text())
-		if err != nil {
-			log.Println(err)
-			return
+	if err := PostReview(config, reviews); err != nil {
+		if metrics != nil {
+			metrics.SlackPostErrorsTotal.Inc()
 		}
+		return err
+	}
 
-		reviewPermalinkNode := s.Find(REVIEW_PERMALINK_CLASS_NAME)
-		reviewPermalink, _ := reviewPermalinkNode.Attr("href")
-
-		reviewTitle := s.Find(REVIEW_TITLE_CLASS_NAME).Text()
-		if len(reviewTitle) == 0 {
-			reviewTitle = "No title provided"
-		}
+	return nil
+}
 
-		reviewMessage := s.Find(REVIEW_MESSAGE_CLASS_NAME).Text()
-		reviewLink := s.Find(REVIEW_LINK_CLASS_NAME).Text()
+func runDaemon(config Config) {
+	pollInterval, err := time.ParseDuration(config.PollInterval)
+	if err != nil {
+		log.Println(err)
+		return
+	}
 
-		reviewMessage = strings.Split(reviewMessage, reviewLink)[0]
+	jitter, err := time.ParseDuration(config.Jitter)
+	if err != nil && config.Jitter != "" {
+		log.Println(err)
+		return
+	}
 
-		reviewRateNode := s.Find(REVIEW_RATE_CLASS_NAME)
-		rateMessage, _ := reviewRateNode.Attr("style")
+	maxConcurrentFetches := config.MaxConcurrentFetches
+	if maxConcurrentFetches < 1 {
+		maxConcurrentFetches = 1
+	}
 
-		rate := parseRate(rateMessage)
+	metrics := daemon.NewMetrics()
+	limiter := daemon.NewLimiter(maxConcurrentFetches)
 
-		review := Review{
-			Author:    authorName,
-			Title:     reviewTitle,
-			Message:   reviewMessage,
-			Rate:      rate,
-			UpdatedAt: date,
-			Permalink: reviewPermalink,
-		}
+	dcfg := daemon.Config{
+		PollInterval: pollInterval,
+		Jitter:       jitter,
+		HealthAddr:   config.HealthAddr,
+	}
 
-		reviews = append(reviews, review)
+	err = daemon.Run(context.Background(), dcfg, func(ctx context.Context) error {
+		limiter.Acquire()
+		defer limiter.Release()
+		return runOnce(config, metrics)
 	})
-
-	sort.Sort(reviews)
-
-	return reviews, nil
-}
-
-func parseRate(message string) string {
-	rateMessage := ""
-
-	switch {
-	case strings.Contains(message, "width: 20%"):
-		rateMessage = strings.Repeat(RATING_EMOJI, 1)
-	case strings.Contains(message, "width: 40%"):
-		rateMessage = strings.Repeat(RATING_EMOJI, 2)
-	case strings.Contains(message, "width: 60%"):
-		rateMessage = strings.Repeat(RATING_EMOJI, 3)
-	case strings.Contains(message, "width: 80%"):
-		rateMessage = strings.Repeat(RATING_EMOJI, 4)
-	case strings.Contains(message, "width: 100%"):
-		rateMessage = strings.Repeat(RATING_EMOJI_2, 5)
+	if err != nil {
+		log.Println(err)
 	}
-
-	return rateMessage
 }
 
 func SaveReviews(reviews Reviews) (Reviews, error) {
@@ -302,7 +307,7 @@ func SaveReviews(reviews Reviews) (Reviews, error) {
 
 	for _, review := range reviews {
 		var id int
-		row := dbh.QueryRow("SELECT id FROM review WHERE comment_uri = $1", review.Permalink)
+		row := dbh.QueryRow("SELECT id FROM review WHERE platform = $1 AND comment_uri = $2", review.Platform, review.Permalink)
 		err := row.Scan(&id)
 
 		if err != nil {
@@ -312,8 +317,8 @@ func SaveReviews(reviews Reviews) (Reviews, error) {
 		}
 
 		if id == 0 {
-			_, err := dbh.Exec("INSERT INTO review (author, comment_uri, updated_at) VALUES ($1, $2, $3)",
-				review.Author, review.Permalink, review.UpdatedAt)
+			_, err := dbh.Exec("INSERT INTO review (author, comment_uri, updated_at, platform) VALUES ($1, $2, $3, $4)",
+				review.Author, review.Permalink, review.UpdatedAt, review.Platform)
 			if err != nil {
 				return postReviews, err
 			}
@@ -324,63 +329,123 @@ func SaveReviews(reviews Reviews) (Reviews, error) {
 	return postReviews, nil
 }
 
-func PostReview(config Config, reviews Reviews) error {
-	attachments := []SlackAttachment{}
+// reviewColor picks a Slack attachment color from the star rating and the
+// lexicon sentiment score: low stars or strongly negative text always wins,
+// otherwise high stars and non-negative sentiment is good, everything else
+// is a mixed/warning signal.
+func reviewColor(stars int, score float64) string {
+	if stars <= 2 || score <= NEGATIVE_SENTIMENT_THRESHOLD {
+		return COLOR_DANGER
+	}
+
+	if stars >= 4 && score >= 0 {
+		return COLOR_GOOD
+	}
+
+	return COLOR_WARNING
+}
+
+func reviewItem(review Review) notify.Item {
+	return notify.Item{
+		Author:    review.Author,
+		Title:     review.Title,
+		Message:   review.Message,
+		Rate:      review.Rate,
+		Stars:     review.Stars,
+		UpdatedAt: review.UpdatedAt,
+		Permalink: review.Permalink,
+		Color:     review.Color,
+	}
+}
 
+// defaultSink reproduces the original single-Slack-webhook behavior when
+// config.Sinks isn't set, so existing config.yml files keep working.
+func defaultSink(config Config) notify.Config {
+	return notify.Config{
+		Type:       "slack",
+		WebHookUri: config.WebHookUri,
+		BotName:    config.BotName,
+		IconEmoji:  config.IconEmoji,
+		Text:       config.MessageText,
+	}
+}
+
+func PostReview(config Config, reviews Reviews) error {
 	if 1 > len(reviews) {
 		return nil
 	}
 
-	for i, review := range reviews {
+	items := []notify.Item{}
+	alertItems := []notify.Item{}
+
+	for i := range reviews {
 		if i >= config.ReviewCount {
 			break
 		}
 
-		fields := []SlackAttachmentField{}
-
-		fields = append(fields, SlackAttachmentField{
-			Title: "Rating",
-			Value: review.Rate,
-			Short: true,
-		})
+		review := &reviews[i]
+		score := sentiment.Score(review.Message)
+		review.Color = reviewColor(review.Stars, score)
 
-		fields = append(fields, SlackAttachmentField{
-			Title: "UpdatedAt",
-			Value: review.UpdatedAt.Format("2006-01-02"),
-			Short: true,
-		})
+		item := reviewItem(*review)
+		items = append(items, item)
 
-		attachments = append(attachments, SlackAttachment{
-			Title:     review.Author,
-			TitleLink: fmt.Sprintf("%s%s", BASE_URI, review.Permalink),
-			Text:      review.Message,
-			Fallback:  review.Message + " " + review.Author,
-			Color:     review.Color,
-			Fields:    fields,
-		})
+		if score < config.AlertThreshold {
+			alertItems = append(alertItems, item)
+		}
 	}
 
-	slackPayload := SlackPayload{
-		UserName:    config.BotName,
-		IconEmoji:   config.IconEmoji,
-		Text:        config.MessageText,
-		Attachments: attachments,
+	sinks := config.Sinks
+	if len(sinks) == 0 {
+		sinks = []notify.Config{defaultSink(config)}
 	}
 
-	payload, err := json.Marshal(slackPayload)
-	if err != nil {
+	if err := fanOut(sinks, items); err != nil {
 		return err
 	}
 
-	req, _ := http.NewRequest("POST", config.WebHookUri, bytes.NewBuffer([]byte(payload)))
-	req.Header.Set("Content-Type", "application/json")
+	if len(alertItems) > 0 && config.AlertWebHookUri != "" {
+		alertSink := defaultSink(config)
+		alertSink.WebHookUri = config.AlertWebHookUri
+		alertSink.Text = "@here " + config.MessageText
 
-	client := http.DefaultClient
-	res, err := client.Do(req)
-	if err != nil {
-		return err
+		if err := fanOut([]notify.Config{alertSink}, alertItems); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fanOut sends items to every sink concurrently, each filtered to that
+// sink's configured star range, and returns the first error encountered.
+func fanOut(sinks []notify.Config, items []notify.Item) error {
+	var wg sync.WaitGroup
+	errs := make(chan error, len(sinks))
+
+	for _, sinkConfig := range sinks {
+		wg.Add(1)
+		go func(sinkConfig notify.Config) {
+			defer wg.Done()
+
+			notifier, err := notify.New(sinkConfig)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			errs <- notifier.Send(sinkConfig.Filter().Apply(items))
+		}(sinkConfig)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return err
+		}
 	}
-	defer res.Body.Close()
 
 	return nil
 }
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestReviewColor(t *testing.T) {
+	cases := []struct {
+		stars int
+		score float64
+		want  string
+	}{
+		{5, 0.8, COLOR_GOOD},
+		{1, 0.5, COLOR_DANGER},
+		{5, -0.9, COLOR_DANGER},
+		{3, 0.1, COLOR_WARNING},
+	}
+
+	for _, c := range cases {
+		if got := reviewColor(c.stars, c.score); got != c.want {
+			t.Errorf("reviewColor(%d, %f) = %s, want %s", c.stars, c.score, got, c.want)
+		}
+	}
+}
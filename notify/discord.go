@@ -0,0 +1,84 @@
+package notify
+
+func init() {
+	RegisterFactory("discord", func(config Config) (Notifier, error) {
+		return NewDiscord(DiscordConfig{
+			WebHookUri: config.WebHookUri,
+			Username:   config.BotName,
+		}), nil
+	})
+}
+
+type DiscordConfig struct {
+	WebHookUri string
+	Username   string
+}
+
+type Discord struct {
+	config DiscordConfig
+}
+
+func NewDiscord(config DiscordConfig) *Discord {
+	return &Discord{config: config}
+}
+
+type discordPayload struct {
+	Username string         `json:"username,omitempty"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	URL         string         `json:"url,omitempty"`
+	Description string         `json:"description"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// discordColor maps the same good/warning/danger vocabulary Slack
+// attachments use onto a Discord embed's decimal RGB color.
+func discordColor(color string) int {
+	switch color {
+	case "good":
+		return 0x2ECC71
+	case "warning":
+		return 0xF1C40F
+	case "danger":
+		return 0xE74C3C
+	default:
+		return 0x95A5A6
+	}
+}
+
+func (d *Discord) Send(items []Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	embeds := make([]discordEmbed, 0, len(items))
+	for _, item := range items {
+		embeds = append(embeds, discordEmbed{
+			Title:       item.Author,
+			URL:         item.Permalink,
+			Description: item.Message,
+			Color:       discordColor(item.Color),
+			Fields: []discordField{
+				{Name: "Rating", Value: item.Rate, Inline: true},
+				{Name: "UpdatedAt", Value: item.UpdatedAt.Format("2006-01-02"), Inline: true},
+			},
+		})
+	}
+
+	payload := discordPayload{
+		Username: d.config.Username,
+		Embeds:   embeds,
+	}
+
+	return postJSON(d.config.WebHookUri, payload)
+}
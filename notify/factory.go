@@ -0,0 +1,40 @@
+package notify
+
+import "fmt"
+
+// Config is one entry of the YAML `sinks` list: which backend to post to,
+// how to reach it, and which star ratings it should receive.
+type Config struct {
+	Type       string `yaml:"type"`
+	WebHookUri string `yaml:"web_hook_uri"`
+	BotName    string `yaml:"bot_name"`
+	IconEmoji  string `yaml:"icon_emoji"`
+	Text       string `yaml:"text"`
+	Template   string `yaml:"template"`
+	MinStars   int    `yaml:"min_stars"`
+	MaxStars   int    `yaml:"max_stars"`
+}
+
+func (config Config) Filter() Filter {
+	return Filter{MinStars: config.MinStars, MaxStars: config.MaxStars}
+}
+
+type factoryFunc func(config Config) (Notifier, error)
+
+var factories = map[string]factoryFunc{}
+
+// RegisterFactory makes a sink type available to New. Each backend file
+// calls this from its own init().
+func RegisterFactory(sinkType string, factory factoryFunc) {
+	factories[sinkType] = factory
+}
+
+// New builds the Notifier for a single configured sink.
+func New(config Config) (Notifier, error) {
+	factory, ok := factories[config.Type]
+	if !ok {
+		return nil, fmt.Errorf("notify: unknown sink type %q", config.Type)
+	}
+
+	return factory(config)
+}
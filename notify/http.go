@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	postAttempts = 3
+	postBackoff  = 500 * time.Millisecond
+)
+
+func postJSON(uri string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return postWithRetry(uri, body)
+}
+
+func postWithRetry(uri string, body []byte) error {
+	var err error
+
+	for attempt := 0; attempt < postAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(postBackoff * time.Duration(1<<uint(attempt-1)))
+		}
+
+		if err = doPost(uri, body); err == nil {
+			return nil
+		}
+
+		if se, ok := err.(*statusError); ok && se.StatusCode >= 400 && se.StatusCode < 500 {
+			return err
+		}
+	}
+
+	return err
+}
+
+// statusError marks a non-2xx response with its status code, so
+// postWithRetry can tell a permanent client error (bad/revoked webhook,
+// 404) from a transient one worth retrying.
+type statusError struct {
+	Uri        string
+	StatusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("notify: %s returned status %d", e.Uri, e.StatusCode)
+}
+
+func doPost(uri string, body []byte) error {
+	req, err := http.NewRequest("POST", uri, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return &statusError{Uri: uri, StatusCode: res.StatusCode}
+	}
+
+	return nil
+}
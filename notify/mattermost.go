@@ -0,0 +1,56 @@
+package notify
+
+func init() {
+	RegisterFactory("mattermost", func(config Config) (Notifier, error) {
+		return NewMattermost(MattermostConfig{
+			WebHookUri: config.WebHookUri,
+			BotName:    config.BotName,
+			IconEmoji:  config.IconEmoji,
+			Text:       config.Text,
+		}), nil
+	})
+}
+
+type MattermostConfig struct {
+	WebHookUri string
+	BotName    string
+	IconEmoji  string
+	Text       string
+}
+
+type Mattermost struct {
+	config MattermostConfig
+}
+
+func NewMattermost(config MattermostConfig) *Mattermost {
+	return &Mattermost{config: config}
+}
+
+// mattermostPayload is Slack-compatible but also supports Mattermost's
+// `props` field, which is what lets a custom "review-notification" card
+// render in the message rather than a plain attachment.
+type mattermostPayload struct {
+	Text        string                 `json:"text"`
+	UserName    string                 `json:"username,omitempty"`
+	IconEmoji   string                 `json:"icon_emoji,omitempty"`
+	Attachments []slackAttachment      `json:"attachments,omitempty"`
+	Props       map[string]interface{} `json:"props,omitempty"`
+}
+
+func (m *Mattermost) Send(items []Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	payload := mattermostPayload{
+		Text:        m.config.Text,
+		UserName:    m.config.BotName,
+		IconEmoji:   m.config.IconEmoji,
+		Attachments: buildSlackAttachments(items),
+		Props: map[string]interface{}{
+			"card": "review-notification",
+		},
+	}
+
+	return postJSON(m.config.WebHookUri, payload)
+}
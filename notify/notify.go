@@ -0,0 +1,48 @@
+// Package notify turns a batch of reviews into a post against one of
+// several chat/webhook backends. Each backend implements Notifier; Config
+// describes one configured sink (its type, webhook, and review filter) and
+// is what the YAML `sinks` list unmarshals into.
+package notify
+
+import "time"
+
+// Item is the backend-agnostic view of a review that every Notifier
+// renders into its own payload shape.
+type Item struct {
+	Author    string
+	Title     string
+	Message   string
+	Rate      string
+	Stars     int
+	UpdatedAt time.Time
+	Permalink string
+	Color     string
+}
+
+// Notifier posts a batch of items to a single destination.
+type Notifier interface {
+	Send(items []Item) error
+}
+
+// Filter narrows a sink to a star-rating range, e.g. routing only 1-2 star
+// reviews to an on-call channel while another sink gets everything.
+type Filter struct {
+	MinStars int
+	MaxStars int
+}
+
+func (f Filter) Apply(items []Item) []Item {
+	max := f.MaxStars
+	if max == 0 {
+		max = 5
+	}
+
+	filtered := make([]Item, 0, len(items))
+	for _, item := range items {
+		if item.Stars >= f.MinStars && item.Stars <= max {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,37 @@
+package notify
+
+import "testing"
+
+func TestFilterApply(t *testing.T) {
+	items := []Item{{Stars: 1}, {Stars: 3}, {Stars: 5}}
+
+	lowStarsOnly := Filter{MinStars: 0, MaxStars: 2}.Apply(items)
+	if len(lowStarsOnly) != 1 || lowStarsOnly[0].Stars != 1 {
+		t.Errorf("expected only the 1-star item, got %v", lowStarsOnly)
+	}
+
+	everything := Filter{}.Apply(items)
+	if len(everything) != 3 {
+		t.Errorf("expected a zero-value filter to pass everything through, got %d items", len(everything))
+	}
+}
+
+func TestNewUnknownSinkType(t *testing.T) {
+	if _, err := New(Config{Type: "carrier_pigeon"}); err == nil {
+		t.Fatal("expected an error for an unregistered sink type")
+	}
+}
+
+func TestNewKnownSinkTypes(t *testing.T) {
+	for _, sinkType := range []string{"slack", "discord", "mattermost", "teams"} {
+		if _, err := New(Config{Type: sinkType, WebHookUri: "https://example.com/hook"}); err != nil {
+			t.Errorf("New(%q) returned error: %v", sinkType, err)
+		}
+	}
+}
+
+func TestWebHookInvalidTemplate(t *testing.T) {
+	if _, err := New(Config{Type: "webhook", Template: "{{ .Unclosed"}); err == nil {
+		t.Fatal("expected an error for an invalid template")
+	}
+}
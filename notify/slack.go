@@ -0,0 +1,84 @@
+package notify
+
+func init() {
+	RegisterFactory("slack", func(config Config) (Notifier, error) {
+		return NewSlack(SlackConfig{
+			WebHookUri: config.WebHookUri,
+			BotName:    config.BotName,
+			IconEmoji:  config.IconEmoji,
+			Text:       config.Text,
+		}), nil
+	})
+}
+
+type SlackConfig struct {
+	WebHookUri string
+	BotName    string
+	IconEmoji  string
+	Text       string
+}
+
+type Slack struct {
+	config SlackConfig
+}
+
+func NewSlack(config SlackConfig) *Slack {
+	return &Slack{config: config}
+}
+
+type slackPayload struct {
+	Text        string            `json:"text"`
+	UserName    string            `json:"username"`
+	IconEmoji   string            `json:"icon_emoji"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Title     string       `json:"title"`
+	TitleLink string       `json:"title_link"`
+	Text      string       `json:"text"`
+	Fallback  string       `json:"fallback"`
+	Color     string       `json:"color"`
+	Fields    []slackField `json:"fields"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+func buildSlackAttachments(items []Item) []slackAttachment {
+	attachments := make([]slackAttachment, 0, len(items))
+
+	for _, item := range items {
+		attachments = append(attachments, slackAttachment{
+			Title:     item.Author,
+			TitleLink: item.Permalink,
+			Text:      item.Message,
+			Fallback:  item.Message + " " + item.Author,
+			Color:     item.Color,
+			Fields: []slackField{
+				{Title: "Rating", Value: item.Rate, Short: true},
+				{Title: "UpdatedAt", Value: item.UpdatedAt.Format("2006-01-02"), Short: true},
+			},
+		})
+	}
+
+	return attachments
+}
+
+func (s *Slack) Send(items []Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	payload := slackPayload{
+		Text:        s.config.Text,
+		UserName:    s.config.BotName,
+		IconEmoji:   s.config.IconEmoji,
+		Attachments: buildSlackAttachments(items),
+	}
+
+	return postJSON(s.config.WebHookUri, payload)
+}
@@ -0,0 +1,68 @@
+package notify
+
+func init() {
+	RegisterFactory("teams", func(config Config) (Notifier, error) {
+		return NewTeams(TeamsConfig{WebHookUri: config.WebHookUri}), nil
+	})
+}
+
+type TeamsConfig struct {
+	WebHookUri string
+}
+
+type Teams struct {
+	config TeamsConfig
+}
+
+func NewTeams(config TeamsConfig) *Teams {
+	return &Teams{config: config}
+}
+
+// teamsCard is a MessageCard, the adaptive card format Teams incoming
+// webhooks expect: https://learn.microsoft.com/outlook/actionable-messages
+type teamsCard struct {
+	Type     string         `json:"@type"`
+	Context  string         `json:"@context"`
+	Summary  string         `json:"summary"`
+	Sections []teamsSection `json:"sections"`
+}
+
+type teamsSection struct {
+	ActivityTitle    string      `json:"activityTitle"`
+	ActivitySubtitle string      `json:"activitySubtitle,omitempty"`
+	Text             string      `json:"text,omitempty"`
+	Facts            []teamsFact `json:"facts"`
+}
+
+type teamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (t *Teams) Send(items []Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	sections := make([]teamsSection, 0, len(items))
+	for _, item := range items {
+		sections = append(sections, teamsSection{
+			ActivityTitle:    item.Author,
+			ActivitySubtitle: item.Permalink,
+			Text:             item.Message,
+			Facts: []teamsFact{
+				{Name: "Rating", Value: item.Rate},
+				{Name: "UpdatedAt", Value: item.UpdatedAt.Format("2006-01-02")},
+			},
+		})
+	}
+
+	card := teamsCard{
+		Type:     "MessageCard",
+		Context:  "http://schema.org/extensions",
+		Summary:  "New review",
+		Sections: sections,
+	}
+
+	return postJSON(t.config.WebHookUri, card)
+}
@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+func init() {
+	RegisterFactory("webhook", func(config Config) (Notifier, error) {
+		return NewWebHook(WebHookConfig{
+			WebHookUri: config.WebHookUri,
+			Template:   config.Template,
+		})
+	})
+}
+
+type WebHookConfig struct {
+	WebHookUri string
+	Template   string
+}
+
+// WebHook posts one request per review, with the JSON body rendered from
+// config.Template so it can target any service a Go text/template can
+// describe.
+type WebHook struct {
+	config WebHookConfig
+	tmpl   *template.Template
+}
+
+func NewWebHook(config WebHookConfig) (*WebHook, error) {
+	tmpl, err := template.New("webhook").Parse(config.Template)
+	if err != nil {
+		return nil, fmt.Errorf("notify: invalid webhook template: %s", err)
+	}
+
+	return &WebHook{config: config, tmpl: tmpl}, nil
+}
+
+func (w *WebHook) Send(items []Item) error {
+	for _, item := range items {
+		var body bytes.Buffer
+		if err := w.tmpl.Execute(&body, item); err != nil {
+			return err
+		}
+
+		if err := postWithRetry(w.config.WebHookUri, body.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
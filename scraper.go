@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+)
+
+const (
+	SOURCE_GOOGLE_PLAY = "google_play"
+	SOURCE_APP_STORE   = "app_store"
+)
+
+// Scraper fetches reviews for a single source given the shared Config.
+type Scraper interface {
+	Fetch(config Config) (Reviews, error)
+}
+
+var scrapers = map[string]Scraper{}
+
+// RegisterScraper makes a Scraper available under name to FetchAll. It is
+// meant to be called from each scraper's init().
+func RegisterScraper(name string, scraper Scraper) {
+	scrapers[name] = scraper
+}
+
+func GetScraper(name string) (Scraper, bool) {
+	scraper, ok := scrapers[name]
+	return scraper, ok
+}
+
+type scraperResult struct {
+	source  string
+	reviews Reviews
+	err     error
+}
+
+// FetchAll runs every scraper in config.Sources concurrently and merges the
+// results into a single, newest-first slice of reviews. A single source
+// failing doesn't fail the whole call: its error is logged and the other
+// sources' reviews are still saved and posted that cycle.
+func FetchAll(config Config) (Reviews, error) {
+	results := make(chan scraperResult, len(config.Sources))
+
+	var wg sync.WaitGroup
+	for _, source := range config.Sources {
+		scraper, ok := GetScraper(source)
+		if !ok {
+			return nil, fmt.Errorf("Unknown Source: %s", source)
+		}
+
+		wg.Add(1)
+		go func(source string, scraper Scraper) {
+			defer wg.Done()
+			reviews, err := scraper.Fetch(config)
+			results <- scraperResult{source: source, reviews: reviews, err: err}
+		}(source, scraper)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	reviews := Reviews{}
+	for result := range results {
+		if result.err != nil {
+			log.Printf("%s: %s", result.source, result.err)
+			continue
+		}
+		reviews = append(reviews, result.reviews...)
+	}
+
+	sort.Sort(reviews)
+
+	return reviews, nil
+}
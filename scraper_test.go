@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// errorScraper always fails, so tests can exercise FetchAll's handling of a
+// single source erroring out alongside others that succeed.
+type errorScraper struct{}
+
+func (errorScraper) Fetch(config Config) (Reviews, error) {
+	return nil, fmt.Errorf("boom")
+}
+
+func TestFetchAllMergesAndSortsNewestFirst(t *testing.T) {
+	RegisterScraper("test_google_play", FileScraper{Path: "testdata/google_play_reviews.json", Platform: SOURCE_GOOGLE_PLAY})
+	RegisterScraper("test_app_store", FileScraper{Path: "testdata/app_store_reviews.json", Platform: SOURCE_APP_STORE})
+
+	config := Config{Sources: []string{"test_google_play", "test_app_store"}}
+
+	reviews, err := FetchAll(config)
+	if err != nil {
+		t.Fatalf("FetchAll returned error: %v", err)
+	}
+
+	if len(reviews) != 3 {
+		t.Fatalf("expected 3 merged reviews, got %d", len(reviews))
+	}
+
+	if reviews[0].Author != "Jon" {
+		t.Errorf("expected newest review first (Jon), got %s", reviews[0].Author)
+	}
+
+	if reviews[len(reviews)-1].Author != "Sansa" {
+		t.Errorf("expected oldest review last (Sansa), got %s", reviews[len(reviews)-1].Author)
+	}
+}
+
+func TestFetchAllToleratesOneSourceFailing(t *testing.T) {
+	RegisterScraper("test_google_play_ok", FileScraper{Path: "testdata/google_play_reviews.json", Platform: SOURCE_GOOGLE_PLAY})
+	RegisterScraper("test_erroring_source", errorScraper{})
+
+	config := Config{Sources: []string{"test_google_play_ok", "test_erroring_source"}}
+
+	reviews, err := FetchAll(config)
+	if err != nil {
+		t.Fatalf("FetchAll returned error: %v", err)
+	}
+
+	if len(reviews) != 2 {
+		t.Fatalf("expected the successful source's 2 reviews to survive, got %d", len(reviews))
+	}
+}
+
+func TestFetchAllUnknownSource(t *testing.T) {
+	config := Config{Sources: []string{"does_not_exist"}}
+
+	if _, err := FetchAll(config); err == nil {
+		t.Fatal("expected an error for an unregistered source")
+	}
+}
@@ -0,0 +1,149 @@
+// Package sentiment scores free-form review text on a simple lexicon
+// basis. It is deliberately not a statistical model: JonSnow only needs a
+// cheap, explainable signal to pick a Slack attachment color and decide
+// whether a review is worth paging someone over.
+package sentiment
+
+import "strings"
+
+const negationWindow = 3
+
+var positiveWords = map[string]float64{
+	"good":      1,
+	"great":     1,
+	"love":      1,
+	"loved":     1,
+	"excellent": 1,
+	"amazing":   1,
+	"awesome":   1,
+	"perfect":   1,
+	"best":      1,
+	"nice":      1,
+	"fantastic": 1,
+	"works":     0.5,
+}
+
+var negativeWords = map[string]float64{
+	"bad":          1,
+	"terrible":     1,
+	"hate":         1,
+	"hated":        1,
+	"awful":        1,
+	"worst":        1,
+	"crash":        1,
+	"crashes":      1,
+	"crashed":      1,
+	"broken":       1,
+	"horrible":     1,
+	"useless":      1,
+	"disappointed": 0.75,
+}
+
+var negators = map[string]bool{
+	"not":     true,
+	"no":      true,
+	"never":   true,
+	"cannot":  true,
+	"can't":   true,
+	"cant":    true,
+	"don't":   true,
+	"dont":    true,
+	"doesn't": true,
+	"doesnt":  true,
+	"isn't":   true,
+	"isnt":    true,
+	"wasn't":  true,
+	"wasnt":   true,
+	"didn't":  true,
+	"didnt":   true,
+}
+
+// Score returns a sentiment value in [-1, 1]: negative for bad reviews,
+// positive for good ones, 0 when the text carries no recognizable
+// sentiment words. A negator within negationWindow tokens before a
+// sentiment word flips its sign, and an ALL-CAPS sentiment word is
+// weighted 1.5x to reflect emphasis.
+func Score(text string) float64 {
+	tokens := tokenize(text)
+
+	var total float64
+	var matched int
+
+	for i, token := range tokens {
+		word := strings.ToLower(token)
+
+		value, isPositive := positiveWords[word]
+		if !isPositive {
+			negValue, isNegative := negativeWords[word]
+			if !isNegative {
+				continue
+			}
+			value = -negValue
+		}
+
+		if isAllCaps(token) {
+			value *= 1.5
+		}
+
+		if negatedWithin(tokens, i, negationWindow) {
+			value = -value
+		}
+
+		total += value
+		matched++
+	}
+
+	if matched == 0 {
+		return 0
+	}
+
+	return clamp(total/float64(matched), -1, 1)
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		switch r {
+		case ' ', '\t', '\n', '\r', '.', ',', '!', '?', ';', ':', '"', '(', ')':
+			return true
+		}
+		return false
+	})
+}
+
+func negatedWithin(tokens []string, idx, window int) bool {
+	start := idx - window
+	if start < 0 {
+		start = 0
+	}
+
+	for i := start; i < idx; i++ {
+		if negators[strings.ToLower(tokens[i])] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAllCaps(token string) bool {
+	hasLetter := false
+	for _, r := range token {
+		if r >= 'a' && r <= 'z' {
+			return false
+		}
+		if r >= 'A' && r <= 'Z' {
+			hasLetter = true
+		}
+	}
+	return hasLetter
+}
+
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
@@ -0,0 +1,51 @@
+package sentiment
+
+import "testing"
+
+func TestScorePositive(t *testing.T) {
+	if score := Score("This app is great, I love it"); score <= 0 {
+		t.Errorf("expected a positive score, got %f", score)
+	}
+}
+
+func TestScoreNegative(t *testing.T) {
+	if score := Score("Terrible app, it crashes constantly"); score >= 0 {
+		t.Errorf("expected a negative score, got %f", score)
+	}
+}
+
+func TestScoreNeutral(t *testing.T) {
+	if score := Score("It does what it says on the tin"); score != 0 {
+		t.Errorf("expected a neutral score for text with no sentiment words, got %f", score)
+	}
+}
+
+func TestScoreNegationFlipsSign(t *testing.T) {
+	positive := Score("great app")
+	negated := Score("not a great app")
+
+	if negated >= 0 {
+		t.Errorf("expected negation within the window to flip the sign, got %f", negated)
+	}
+
+	if negated != -positive {
+		t.Errorf("expected negation to flip magnitude exactly, got %f want %f", negated, -positive)
+	}
+}
+
+func TestScoreNegationOutsideWindowDoesNotFlip(t *testing.T) {
+	// "not" is 7 tokens before "great", outside the 3-token window.
+	score := Score("not even remotely close to being a great app")
+	if score <= 0 {
+		t.Errorf("expected negation outside the window to leave sign unchanged, got %f", score)
+	}
+}
+
+func TestScoreAllCapsEmphasis(t *testing.T) {
+	lower := Score("this app works great")
+	upper := Score("this app works GREAT")
+
+	if upper <= lower {
+		t.Errorf("expected ALL-CAPS sentiment word to score higher, got upper=%f lower=%f", upper, lower)
+	}
+}